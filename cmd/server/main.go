@@ -1,15 +1,39 @@
 package main
 
+// NOTE: swag (github.com/swaggo/swag) only emits Swagger 2.0, not the OpenAPI 3
+// spec checked in under docs/, so it cannot generate docs/swagger.json and
+// docs/swagger.yaml directly — those are hand-maintained to match the
+// @Summary/@Param/@Success/@Failure annotations below and on the handlers.
+//
+// The go:generate directive below still regenerates a Swagger 2.0 doc from
+// those same annotations into docs/swagger2/, purely as a drift-detection
+// artifact: if a field is added to a request/response type and the
+// annotations aren't updated to match, `go generate ./...` followed by
+// `git diff docs/swagger2` will show it. Nothing serves docs/swagger2 at
+// runtime.
+//
+//go:generate go run github.com/swaggo/swag/cmd/swag init -g main.go -o ../../docs/swagger2 --parseDependency --parseInternal
+
 import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	echoSwagger "github.com/swaggo/echo-swagger"
+
 	"github.com/thevibhu/plusgrade-tax-calculator/config"
+	"github.com/thevibhu/plusgrade-tax-calculator/docs"
 	"github.com/thevibhu/plusgrade-tax-calculator/internal/handler"
+	appmiddleware "github.com/thevibhu/plusgrade-tax-calculator/internal/middleware"
+	"github.com/thevibhu/plusgrade-tax-calculator/internal/metrics"
 	"github.com/thevibhu/plusgrade-tax-calculator/internal/service"
 )
 
+// @title Plusgrade Tax Calculator API
+// @version 1.0
+// @description REST API for calculating Canadian federal income tax across supported tax years.
+// @BasePath /
 func main() {
 	// Load configuration
 	cfg := config.Load()
@@ -21,17 +45,25 @@ func main() {
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
+	e.Use(appmiddleware.RequestID())
+	e.Use(metrics.Middleware())
 
 	// Initialize service layer
-	taxService := service.NewTaxService(cfg.TaxAPIURL)
+	upstreamTaxService := service.NewTaxService(cfg.TaxAPIURL, service.DefaultRetryPolicy())
+	taxService := service.NewCachedTaxService(upstreamTaxService, cfg.TaxCacheTTL)
 
 	// Initialize handlers
-	taxHandler := handler.NewTaxHandler(taxService)
+	taxHandler := handler.NewTaxHandler(taxService, cfg.RequestTimeout)
 
 	// Routes
 	e.GET("/health", healthCheck)
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+	e.GET("/openapi.json", docs.Handler)
+	e.GET("/docs/*", echoSwagger.EchoWrapHandler(echoSwagger.URL("/openapi.json")))
 	e.POST("/tax/calculate", taxHandler.CalculateTax)
+	e.POST("/tax/calculate/batch", taxHandler.CalculateTaxBatch)
 	e.GET("/tax/brackets/:year", taxHandler.GetTaxBrackets)
+	e.DELETE("/tax/brackets/:year/cache", taxHandler.InvalidateCache)
 
 	// Start server
 	log.Info().Msgf("Starting server on port %s", cfg.Port)
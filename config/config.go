@@ -2,17 +2,22 @@ package config
 
 import (
 	"os"
+	"time"
 )
 
 type Config struct {
-	Port      string
-	TaxAPIURL string
+	Port           string
+	TaxAPIURL      string
+	TaxCacheTTL    time.Duration
+	RequestTimeout time.Duration
 }
 
 func Load() *Config {
 	return &Config{
-		Port:      getEnv("PORT", "8080"),
-		TaxAPIURL: getEnv("TAX_API_URL", "http://tax-api:5001"),
+		Port:           getEnv("PORT", "8080"),
+		TaxAPIURL:      getEnv("TAX_API_URL", "http://tax-api:5001"),
+		TaxCacheTTL:    getEnvDuration("TAX_CACHE_TTL", time.Hour),
+		RequestTimeout: getEnvDuration("REQUEST_TIMEOUT", 5*time.Second),
 	}
 }
 
@@ -22,3 +27,12 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
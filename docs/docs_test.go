@@ -0,0 +1,41 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHandlerServesOpenAPISpec verifies that the /openapi.json route returns
+// the embedded spec with the documented paths and schemas present.
+func TestHandlerServesOpenAPISpec(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, Handler(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var spec map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+
+	paths, ok := spec["paths"].(map[string]interface{})
+	assert.True(t, ok, "spec should have a paths object")
+	assert.Contains(t, paths, "/tax/calculate")
+	assert.Contains(t, paths, "/tax/calculate/batch")
+	assert.Contains(t, paths, "/tax/brackets/{year}")
+
+	components, ok := spec["components"].(map[string]interface{})
+	assert.True(t, ok, "spec should have a components object")
+	schemas, ok := components["schemas"].(map[string]interface{})
+	assert.True(t, ok, "components should have a schemas object")
+	assert.Contains(t, schemas, "TaxCalculationRequest")
+	assert.Contains(t, schemas, "TaxCalculationResponse")
+	assert.Contains(t, schemas, "BandTaxDetail")
+	assert.Contains(t, schemas, "APIErrorResponse")
+}
@@ -1,24 +1,72 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"runtime"
+	"sync"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/thevibhu/plusgrade-tax-calculator/internal/models"
 	"github.com/thevibhu/plusgrade-tax-calculator/internal/service"
 )
 
+// maxBatchSize bounds how many items a single POST /tax/calculate/batch
+// request may contain.
+const maxBatchSize = 100
+
+var validTaxYears = map[string]bool{"2019": true, "2020": true, "2021": true, "2022": true}
+
 type TaxHandler struct {
-	taxService service.TaxService
+	taxService     service.TaxService
+	requestTimeout time.Duration
 }
 
-func NewTaxHandler(taxService service.TaxService) *TaxHandler {
+// NewTaxHandler builds a TaxHandler. requestTimeout bounds how long a single
+// request is allowed to wait on the upstream API, independent of the
+// transport-level timeout configured on the HTTP client itself.
+func NewTaxHandler(taxService service.TaxService, requestTimeout time.Duration) *TaxHandler {
 	return &TaxHandler{
-		taxService: taxService,
+		taxService:     taxService,
+		requestTimeout: requestTimeout,
+	}
+}
+
+// writeServiceError maps a TaxService error to the appropriate HTTP response:
+// a cancelled or timed-out request context yields 504, a structured upstream
+// API error is passed through as 502, and anything else is a generic 500.
+func writeServiceError(c echo.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return c.JSON(http.StatusGatewayTimeout, map[string]string{
+			"error": "Request timed out waiting for the upstream tax API.",
+		})
+	}
+
+	var apiError *service.APIErrorResponse
+	if errors.As(err, &apiError) {
+		return c.JSON(http.StatusBadGateway, apiError)
 	}
+
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": "An internal server error occurred.",
+	})
 }
 
+// CalculateTax godoc
+//
+// @Summary Calculate income tax
+// @Description Calculates total tax, effective rate, and per-bracket breakdown for a given income and tax year.
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param request body models.TaxCalculationRequest true "Income and tax year"
+// @Success 200 {object} models.TaxCalculationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 502 {object} service.APIErrorResponse
+// @Failure 504 {object} map[string]string
+// @Router /tax/calculate [post]
 func (h *TaxHandler) CalculateTax(c echo.Context) error {
 	var req models.TaxCalculationRequest
 
@@ -35,55 +83,192 @@ func (h *TaxHandler) CalculateTax(c echo.Context) error {
 		})
 	}
 
-	validYears := map[string]bool{"2019": true, "2020": true, "2021": true, "2022": true}
-	if !validYears[req.TaxYear] {
+	if !validTaxYears[req.TaxYear] {
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "Tax year must be one of: 2019, 2020, 2021, 2022",
 		})
 	}
 
-	result, err := h.taxService.CalculateTax(req.Income, req.TaxYear)
-	if err != nil {
-		var apiError *service.APIErrorResponse
-
-		// Check if the error is the specific API error type.
-		if errors.As(err, &apiError) {
-			return c.JSON(http.StatusBadGateway, apiError)
-		}
+	ctx, cancel := context.WithTimeout(c.Request().Context(), h.requestTimeout)
+	defer cancel()
 
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "An internal server error occurred.",
-		})
+	result, err := h.taxService.CalculateTax(ctx, req.Income, req.TaxYear)
+	if err != nil {
+		return writeServiceError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, result)
 }
 
+// GetTaxBrackets godoc
+//
+// @Summary Get tax brackets for a year
+// @Tags tax
+// @Produce json
+// @Param year path string true "Tax year" Enums(2019, 2020, 2021, 2022)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 502 {object} service.APIErrorResponse
+// @Router /tax/brackets/{year} [get]
 func (h *TaxHandler) GetTaxBrackets(c echo.Context) error {
 	year := c.Param("year")
 
-	validYears := map[string]bool{"2019": true, "2020": true, "2021": true, "2022": true}
-	if !validYears[year] {
+	if !validTaxYears[year] {
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "Tax year must be one of: 2019, 2020, 2021, 2022",
 		})
 	}
 
-	brackets, err := h.taxService.GetTaxBrackets(year)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), h.requestTimeout)
+	defer cancel()
+
+	brackets, err := h.taxService.GetTaxBrackets(ctx, year)
 	if err != nil {
-		var apiError *service.APIErrorResponse
+		return writeServiceError(c, err)
+	}
 
-		// Check if the error is the specific API error type.
-		if errors.As(err, &apiError) {
-			return c.JSON(http.StatusBadGateway, apiError)
-		}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"tax_brackets": brackets,
+	})
+}
 
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "An internal server error occurred.",
+// CalculateTaxBatch godoc
+//
+// @Summary Calculate tax for a batch of incomes
+// @Description Evaluates up to 100 (income, tax_year) pairs concurrently, preserving request order and reporting per-item failures.
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param request body models.BatchTaxCalculationRequest true "Batch of income/tax-year pairs, max 100"
+// @Success 200 {object} models.BatchTaxCalculationResponse
+// @Failure 400 {object} map[string]string
+// @Router /tax/calculate/batch [post]
+//
+// CalculateTaxBatch computes tax for a list of (income, tax_year) pairs. It
+// prefetches brackets for each distinct tax year up front so that a cache miss
+// for one year doesn't stall items for another, then evaluates every item
+// concurrently over a bounded worker pool. Results preserve request order and
+// report per-item failures rather than failing the whole batch.
+func (h *TaxHandler) CalculateTaxBatch(c echo.Context) error {
+	var req models.BatchTaxCalculationRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request format",
 		})
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"tax_brackets": brackets,
+	if len(req.Requests) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "At least one request is required",
+		})
+	}
+	if len(req.Requests) > maxBatchSize {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A batch may contain at most 100 requests",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), h.requestTimeout)
+	defer cancel()
+
+	h.prefetchBrackets(ctx, req.Requests)
+
+	results := make([]models.BatchTaxCalculationItemResult, len(req.Requests))
+	for i := range results {
+		// Pre-populate with the real index and a cancellation error so that,
+		// if ctx is cancelled before a job is dispatched to a worker, the
+		// result isn't left as a zero value indistinguishable from a
+		// successful item 0.
+		results[i] = models.BatchTaxCalculationItemResult{Index: i, Error: "Request was cancelled before this item could be calculated"}
+	}
+
+	type job struct {
+		index int
+		item  models.TaxCalculationRequest
+	}
+	jobs := make(chan job)
+
+	workerCount := runtime.NumCPU()
+	if workerCount > len(req.Requests) {
+		workerCount = len(req.Requests)
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < workerCount; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for j := range jobs {
+				results[j.index] = h.calculateBatchItem(ctx, j.index, j.item)
+			}
+		}()
+	}
+
+	for i, item := range req.Requests {
+		select {
+		case jobs <- job{index: i, item: item}:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	workers.Wait()
+
+	return c.JSON(http.StatusOK, models.BatchTaxCalculationResponse{Results: results})
+}
+
+// prefetchBrackets fetches brackets for every distinct tax year referenced in
+// requests, concurrently, so that the worker pool in CalculateTaxBatch hits a
+// warm cache. Fetch errors are ignored here; a year whose fetch failed is
+// fetched again by each item that needs it.
+func (h *TaxHandler) prefetchBrackets(ctx context.Context, requests []models.TaxCalculationRequest) {
+	years := make(map[string]struct{})
+	for _, item := range requests {
+		if validTaxYears[item.TaxYear] {
+			years[item.TaxYear] = struct{}{}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for year := range years {
+		wg.Add(1)
+		go func(year string) {
+			defer wg.Done()
+			h.taxService.GetTaxBrackets(ctx, year)
+		}(year)
+	}
+	wg.Wait()
+}
+
+func (h *TaxHandler) calculateBatchItem(ctx context.Context, index int, item models.TaxCalculationRequest) models.BatchTaxCalculationItemResult {
+	if item.Income < 0 {
+		return models.BatchTaxCalculationItemResult{Index: index, Error: "Income must be non-negative"}
+	}
+	if !validTaxYears[item.TaxYear] {
+		return models.BatchTaxCalculationItemResult{Index: index, Error: "Tax year must be one of: 2019, 2020, 2021, 2022"}
+	}
+
+	result, err := h.taxService.CalculateTax(ctx, item.Income, item.TaxYear)
+	if err != nil {
+		var apiError *service.APIErrorResponse
+		if errors.As(err, &apiError) {
+			return models.BatchTaxCalculationItemResult{Index: index, Error: apiError}
+		}
+		return models.BatchTaxCalculationItemResult{Index: index, Error: err.Error()}
+	}
+
+	return models.BatchTaxCalculationItemResult{Index: index, Result: result}
+}
+
+// InvalidateCache drops any cached tax brackets for the given year, forcing the
+// next request for that year to re-fetch from the upstream API.
+func (h *TaxHandler) InvalidateCache(c echo.Context) error {
+	year := c.Param("year")
+
+	h.taxService.InvalidateCache(year)
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"status": "cache invalidated",
+		"year":   year,
 	})
 }
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thevibhu/plusgrade-tax-calculator/internal/models"
+	"github.com/thevibhu/plusgrade-tax-calculator/internal/service"
+)
+
+func newTestHandler(t *testing.T, handlerFn http.HandlerFunc) (*TaxHandler, *int32) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		handlerFn(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	upstream := service.NewTaxService(server.URL, service.DefaultRetryPolicy())
+	cached := service.NewCachedTaxService(upstream, time.Minute)
+
+	return NewTaxHandler(cached, 5*time.Second), &requestCount
+}
+
+func bracketsResponse() string {
+	return `{"tax_brackets":[{"min":0,"max":50197,"rate":0.15},{"min":50197,"rate":0.2}]}`
+}
+
+func TestCalculateTaxBatch(t *testing.T) {
+	t.Run("Preserves ordering and surfaces partial failures", func(t *testing.T) {
+		handler, _ := newTestHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(bracketsResponse()))
+		})
+
+		body := `{"requests":[
+			{"income":50000,"tax_year":"2022"},
+			{"income":10000,"tax_year":"not-a-year"},
+			{"income":100000,"tax_year":"2022"}
+		]}`
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/tax/calculate/batch", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, handler.CalculateTaxBatch(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp models.BatchTaxCalculationResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Len(t, resp.Results, 3)
+
+		assert.Equal(t, 0, resp.Results[0].Index)
+		assert.NotNil(t, resp.Results[0].Result)
+		assert.Empty(t, resp.Results[0].Error)
+
+		assert.Equal(t, 1, resp.Results[1].Index)
+		assert.Nil(t, resp.Results[1].Result)
+		assert.NotEmpty(t, resp.Results[1].Error)
+
+		assert.Equal(t, 2, resp.Results[2].Index)
+		assert.NotNil(t, resp.Results[2].Result)
+		assert.Empty(t, resp.Results[2].Error)
+	})
+
+	t.Run("Concurrent same-year requests trigger a single upstream fetch", func(t *testing.T) {
+		handler, requestCount := newTestHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(bracketsResponse()))
+		})
+
+		body := `{"requests":[
+			{"income":50000,"tax_year":"2022"},
+			{"income":60000,"tax_year":"2022"},
+			{"income":70000,"tax_year":"2022"},
+			{"income":80000,"tax_year":"2022"}
+		]}`
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/tax/calculate/batch", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, handler.CalculateTaxBatch(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, int32(1), atomic.LoadInt32(requestCount))
+	})
+
+	t.Run("Rejects a batch larger than the allowed maximum", func(t *testing.T) {
+		handler, _ := newTestHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(bracketsResponse()))
+		})
+
+		items := make([]string, 0, maxBatchSize+1)
+		for i := 0; i < maxBatchSize+1; i++ {
+			items = append(items, `{"income":1000,"tax_year":"2022"}`)
+		}
+		body := `{"requests":[` + strings.Join(items, ",") + `]}`
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/tax/calculate/batch", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, handler.CalculateTaxBatch(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Surfaces a structured upstream API error as a JSON object, not a stringified blob", func(t *testing.T) {
+		handler, _ := newTestHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"code":"YEAR_NOT_FOUND","field":"tax_year","message":"no data for requested year"}]}`))
+		})
+
+		body := `{"requests":[{"income":50000,"tax_year":"2022"}]}`
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/tax/calculate/batch", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, handler.CalculateTaxBatch(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var raw map[string]json.RawMessage
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+
+		var results []map[string]json.RawMessage
+		assert.NoError(t, json.Unmarshal(raw["results"], &results))
+		assert.Len(t, results, 1)
+
+		var apiError service.APIErrorResponse
+		assert.NoError(t, json.Unmarshal(results[0]["error"], &apiError))
+		assert.Equal(t, "YEAR_NOT_FOUND", apiError.Errors[0].Code)
+	})
+
+	t.Run("Preserves distinct indices when the request is cancelled mid-dispatch", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(500 * time.Millisecond):
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+		defer server.Close()
+
+		upstream := service.NewTaxService(server.URL, service.DefaultRetryPolicy())
+		cached := service.NewCachedTaxService(upstream, time.Minute)
+		handler := NewTaxHandler(cached, 10*time.Millisecond)
+
+		items := make([]string, 0, 20)
+		for i := 0; i < 20; i++ {
+			items = append(items, `{"income":1000,"tax_year":"2022"}`)
+		}
+		body := `{"requests":[` + strings.Join(items, ",") + `]}`
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/tax/calculate/batch", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, handler.CalculateTaxBatch(c))
+
+		var resp models.BatchTaxCalculationResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Len(t, resp.Results, 20)
+
+		seenIndices := make(map[int]bool)
+		for _, result := range resp.Results {
+			assert.False(t, seenIndices[result.Index], "index %d reported more than once", result.Index)
+			seenIndices[result.Index] = true
+			if result.Result == nil {
+				assert.NotEmpty(t, result.Error)
+			}
+		}
+		assert.Len(t, seenIndices, 20)
+	})
+}
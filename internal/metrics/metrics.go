@@ -0,0 +1,81 @@
+// Package metrics defines the Prometheus collectors exposed by the service and
+// the Echo middleware that feeds them.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// TaxRequestsTotal counts handled HTTP requests by route and response status.
+	TaxRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tax_requests_total",
+		Help: "Total number of tax API requests, by endpoint and response status.",
+	}, []string{"endpoint", "status"})
+
+	// TaxCalculationDuration measures how long a request takes end to end, by
+	// route. Observed once per request from Middleware, so coverage doesn't
+	// depend on a handler remembering to record it.
+	TaxCalculationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tax_calculation_duration_seconds",
+		Help:    "Duration of tax API requests in seconds, by endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	// UpstreamAPIRequestsTotal counts calls made to the upstream tax bracket API.
+	UpstreamAPIRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upstream_api_requests_total",
+		Help: "Total number of requests made to the upstream tax bracket API, by response status.",
+	}, []string{"status"})
+
+	// UpstreamAPIDuration measures the latency of calls to the upstream tax bracket API.
+	UpstreamAPIDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "upstream_api_duration_seconds",
+		Help:    "Duration of calls to the upstream tax bracket API in seconds.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// TaxBracketsCacheEntries reports how many tax years are currently cached.
+	TaxBracketsCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tax_brackets_cache_entries",
+		Help: "Number of tax years currently held in the in-memory brackets cache.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		TaxRequestsTotal,
+		TaxCalculationDuration,
+		UpstreamAPIRequestsTotal,
+		UpstreamAPIDuration,
+		TaxBracketsCacheEntries,
+	)
+}
+
+// Middleware returns Echo middleware that records tax_requests_total and
+// tax_calculation_duration_seconds for every handled request, both keyed by route.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			duration := time.Since(start).Seconds()
+
+			status := c.Response().Status
+			if err != nil {
+				if he, ok := err.(*echo.HTTPError); ok {
+					status = he.Code
+				}
+			}
+
+			TaxRequestsTotal.WithLabelValues(c.Path(), strconv.Itoa(status)).Inc()
+			TaxCalculationDuration.WithLabelValues(c.Path()).Observe(duration)
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/thevibhu/plusgrade-tax-calculator/internal/requestid"
+)
+
+// RequestID returns Echo middleware that reads the inbound X-Request-ID header,
+// generating a UUID if absent, echoes it back on the response, and stashes it
+// on the request context so downstream layers (logging, the upstream API
+// client) can correlate with it.
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.Request().Header.Get(requestid.Header)
+			if id == "" {
+				id = uuid.NewString()
+			}
+			c.Response().Header().Set(requestid.Header, id)
+
+			ctx := requestid.NewContext(c.Request().Context(), id)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
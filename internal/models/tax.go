@@ -36,3 +36,26 @@ type BandTaxDetail struct {
 	TaxableIncome float64 `json:"taxable_income"`
 	TaxAmount     float64 `json:"tax_amount"`
 }
+
+// BatchTaxCalculationRequest is the request body for POST /tax/calculate/batch
+type BatchTaxCalculationRequest struct {
+	Requests []TaxCalculationRequest `json:"requests" validate:"required"`
+}
+
+// BatchTaxCalculationItemResult holds the outcome of a single item in a batch
+// request. Exactly one of Result or Error is populated. Error is a structured
+// object (e.g. *service.APIErrorResponse) when the upstream tax bracket API
+// returned a structured error, and a plain string for every other failure
+// (validation, cancellation, unstructured upstream errors), so callers can
+// distinguish the two without scraping a stringified message.
+type BatchTaxCalculationItemResult struct {
+	Index  int                     `json:"index"`
+	Result *TaxCalculationResponse `json:"result,omitempty"`
+	Error  interface{}             `json:"error,omitempty"`
+}
+
+// BatchTaxCalculationResponse is the response body for POST /tax/calculate/batch.
+// Results are returned in the same order as the requests that produced them.
+type BatchTaxCalculationResponse struct {
+	Results []BatchTaxCalculationItemResult `json:"results"`
+}
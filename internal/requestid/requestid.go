@@ -0,0 +1,23 @@
+// Package requestid carries a per-request correlation ID through context.Context
+// so that logs and upstream API calls can be tied back to the inbound request
+// that triggered them.
+package requestid
+
+import "context"
+
+type contextKey struct{}
+
+// Header is the HTTP header used to propagate the request ID, both inbound
+// (from a client) and outbound (to the upstream tax bracket API).
+const Header = "X-Request-ID"
+
+// NewContext returns a context carrying the given request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or "" if none is set.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/thevibhu/plusgrade-tax-calculator/internal/metrics"
+	"github.com/thevibhu/plusgrade-tax-calculator/internal/models"
+)
+
+type cacheEntry struct {
+	brackets []models.TaxBracket
+	expires  time.Time
+}
+
+// cachedTaxService decorates a TaxService with an in-memory, per-year cache of
+// tax brackets. Brackets for a given year are effectively immutable, so
+// caching them avoids re-hitting the upstream API on every request.
+type cachedTaxService struct {
+	inner TaxService
+	ttl   time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+
+	group singleflight.Group
+}
+
+// NewCachedTaxService wraps inner with a TTL-bounded cache of tax brackets,
+// collapsing concurrent misses for the same year into a single upstream call.
+func NewCachedTaxService(inner TaxService, ttl time.Duration) TaxService {
+	return &cachedTaxService{
+		inner:   inner,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (s *cachedTaxService) GetTaxBrackets(ctx context.Context, year string) ([]models.TaxBracket, error) {
+	if brackets, ok := s.lookup(year); ok {
+		return brackets, nil
+	}
+
+	result, err, _ := s.group.Do(year, func() (interface{}, error) {
+		if brackets, ok := s.lookup(year); ok {
+			return brackets, nil
+		}
+
+		brackets, err := s.inner.GetTaxBrackets(ctx, year)
+		if err != nil {
+			return nil, err
+		}
+
+		s.store(year, brackets)
+		return brackets, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]models.TaxBracket), nil
+}
+
+func (s *cachedTaxService) CalculateTax(ctx context.Context, income float64, year string) (*models.TaxCalculationResponse, error) {
+	brackets, err := s.GetTaxBrackets(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateTax(income, year, brackets)
+}
+
+func (s *cachedTaxService) InvalidateCache(year string) {
+	s.mu.Lock()
+	delete(s.entries, year)
+	s.mu.Unlock()
+	metrics.TaxBracketsCacheEntries.Set(float64(s.cacheSize()))
+}
+
+func (s *cachedTaxService) lookup(year string) ([]models.TaxBracket, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.entries[year]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.brackets, true
+}
+
+func (s *cachedTaxService) store(year string, brackets []models.TaxBracket) {
+	s.mu.Lock()
+	s.entries[year] = cacheEntry{
+		brackets: brackets,
+		expires:  time.Now().Add(s.ttl),
+	}
+	size := len(s.entries)
+	s.mu.Unlock()
+
+	metrics.TaxBracketsCacheEntries.Set(float64(size))
+}
+
+// cacheSize reports the number of cached tax years, used to report the
+// tax_brackets_cache_entries metric.
+func (s *cachedTaxService) cacheSize() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
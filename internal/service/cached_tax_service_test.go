@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCountingBracketsServer(t *testing.T) (*httptest.Server, *int32) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"tax_brackets":[{"min":0,"max":50197,"rate":0.15}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requestCount
+}
+
+func TestCachedTaxService(t *testing.T) {
+	t.Run("Second call within TTL does not hit upstream", func(t *testing.T) {
+		server, requestCount := newCountingBracketsServer(t)
+
+		inner := NewTaxService(server.URL, DefaultRetryPolicy())
+		cached := NewCachedTaxService(inner, time.Minute)
+
+		_, err := cached.GetTaxBrackets(context.Background(), "2022")
+		assert.NoError(t, err)
+
+		_, err = cached.GetTaxBrackets(context.Background(), "2022")
+		assert.NoError(t, err)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(requestCount))
+	})
+
+	t.Run("Concurrent misses trigger exactly one upstream request", func(t *testing.T) {
+		server, requestCount := newCountingBracketsServer(t)
+
+		inner := NewTaxService(server.URL, DefaultRetryPolicy())
+		cached := NewCachedTaxService(inner, time.Minute)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := cached.GetTaxBrackets(context.Background(), "2023")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(requestCount))
+	})
+
+	t.Run("Fresh fetch occurs after TTL expiry", func(t *testing.T) {
+		server, requestCount := newCountingBracketsServer(t)
+
+		inner := NewTaxService(server.URL, DefaultRetryPolicy())
+		cached := NewCachedTaxService(inner, 10*time.Millisecond)
+
+		_, err := cached.GetTaxBrackets(context.Background(), "2022")
+		assert.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, err = cached.GetTaxBrackets(context.Background(), "2022")
+		assert.NoError(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(requestCount))
+	})
+
+	t.Run("InvalidateCache forces a re-fetch", func(t *testing.T) {
+		server, requestCount := newCountingBracketsServer(t)
+
+		inner := NewTaxService(server.URL, DefaultRetryPolicy())
+		cached := NewCachedTaxService(inner, time.Minute)
+
+		_, err := cached.GetTaxBrackets(context.Background(), "2022")
+		assert.NoError(t, err)
+
+		cached.InvalidateCache("2022")
+
+		_, err = cached.GetTaxBrackets(context.Background(), "2022")
+		assert.NoError(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(requestCount))
+	})
+
+	t.Run("CalculateTax errors when upstream returns no brackets", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"tax_brackets":[]}`))
+		}))
+		defer server.Close()
+
+		inner := NewTaxService(server.URL, DefaultRetryPolicy())
+		cached := NewCachedTaxService(inner, time.Minute)
+
+		result, err := cached.CalculateTax(context.Background(), 50000, "2022")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -1,34 +1,60 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/thevibhu/plusgrade-tax-calculator/internal/metrics"
 	"github.com/thevibhu/plusgrade-tax-calculator/internal/models"
+	"github.com/thevibhu/plusgrade-tax-calculator/internal/requestid"
 )
 
 type TaxService interface {
-	GetTaxBrackets(year string) ([]models.TaxBracket, error)
-	CalculateTax(income float64, year string) (*models.TaxCalculationResponse, error)
+	GetTaxBrackets(ctx context.Context, year string) ([]models.TaxBracket, error)
+	CalculateTax(ctx context.Context, income float64, year string) (*models.TaxCalculationResponse, error)
+	// InvalidateCache drops any cached data for the given tax year. Implementations
+	// without a cache treat this as a no-op.
+	InvalidateCache(year string)
+}
+
+// RetryPolicy configures how upstream API calls are retried on transient failures.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is explicitly configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+	}
 }
 
 type taxService struct {
-	apiURL     string
-	httpClient *http.Client
+	apiURL      string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
 }
 
-func NewTaxService(apiURL string) TaxService {
+func NewTaxService(apiURL string, retryPolicy RetryPolicy) TaxService {
 	return &taxService{
 		apiURL: apiURL,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		retryPolicy: retryPolicy,
 	}
 }
 
@@ -54,14 +80,143 @@ func (e *APIErrorResponse) Error() string {
 	return string(jsonData)
 }
 
-func (s *taxService) GetTaxBrackets(year string) ([]models.TaxBracket, error) {
+// isRetryableStatus reports whether a response status code indicates a transient
+// upstream failure worth retrying.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, which per RFC 7231 may be either
+// a number of seconds or an HTTP-date. It returns zero if the header is absent,
+// unparseable, or already in the past.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if when, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+// backoffDelay computes how long to wait before the next retry attempt. It
+// honors an explicit Retry-After delay when present, otherwise falls back to
+// exponential backoff capped at MaxDelay, plus 0-99ms of random jitter added
+// after the cap.
+func (p RetryPolicy) backoffDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	delay := p.BaseDelay * time.Duration(1<<uint(attempt))
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay + time.Duration(rand.Intn(100))*time.Millisecond
+}
+
+// sleepOrDone waits for d, or returns ctx.Err() early if ctx is cancelled or its
+// deadline elapses first. This lets a cancelled request abort mid-backoff
+// instead of waiting out the full retry delay.
+func sleepOrDone(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+func (s *taxService) GetTaxBrackets(ctx context.Context, year string) ([]models.TaxBracket, error) {
 	url := fmt.Sprintf("%s/tax-calculator/tax-year/%s", s.apiURL, year)
+	reqID := requestid.FromContext(ctx)
+
+	var lastErr error
+
+	for attempt := 0; attempt < s.retryPolicy.MaxAttempts; attempt++ {
+		resp, err := s.doUpstreamRequest(ctx, url, reqID)
+		if err != nil {
+			lastErr = err
+			log.Error().Err(err).Str("request_id", reqID).Int("attempt", attempt+1).Msg("Error fetching tax brackets")
+
+			if attempt < s.retryPolicy.MaxAttempts-1 {
+				if sleepErr := sleepOrDone(ctx, s.retryPolicy.backoffDelay(attempt, 0)); sleepErr != nil {
+					return nil, sleepErr
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		if isRetryableStatus(resp.StatusCode) && attempt < s.retryPolicy.MaxAttempts-1 {
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+			resp.Body.Close()
+
+			delay := s.retryPolicy.backoffDelay(attempt, retryAfter)
+			log.Warn().Int("status", resp.StatusCode).Str("request_id", reqID).Int("attempt", attempt+1).
+				Dur("delay", delay).Msg("Retrying after transient upstream failure")
 
-	resp, err := s.httpClient.Get(url)
+			if sleepErr := sleepOrDone(ctx, delay); sleepErr != nil {
+				return nil, sleepErr
+			}
+			continue
+		}
+
+		brackets, err := s.handleBracketsResponse(resp, year)
+		if err != nil {
+			lastErr = err
+		}
+		return brackets, err
+	}
+
+	return nil, lastErr
+}
+
+// doUpstreamRequest performs a single GET against the upstream API, recording
+// the upstream_api_requests_total and upstream_api_duration_seconds metrics and
+// propagating the caller's request ID for cross-service log correlation.
+func (s *taxService) doUpstreamRequest(ctx context.Context, url, reqID string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		log.Error().Err(err).Msg("Error fetching tax brackets")
 		return nil, err
 	}
+	if reqID != "" {
+		req.Header.Set(requestid.Header, reqID)
+	}
+
+	start := time.Now()
+	resp, err := s.httpClient.Do(req)
+	metrics.UpstreamAPIDuration.Observe(time.Since(start).Seconds())
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	metrics.UpstreamAPIRequestsTotal.WithLabelValues(status).Inc()
+
+	return resp, err
+}
+
+// handleBracketsResponse decodes a single upstream response, taking ownership of
+// closing resp.Body.
+func (s *taxService) handleBracketsResponse(resp *http.Response, year string) ([]models.TaxBracket, error) {
 	defer resp.Body.Close()
 
 	// Handle non-200 responses
@@ -93,18 +248,44 @@ func (s *taxService) GetTaxBrackets(year string) ([]models.TaxBracket, error) {
 	return response.TaxBrackets, nil
 }
 
-func (s *taxService) CalculateTax(income float64, year string) (*models.TaxCalculationResponse, error) {
+// InvalidateCache is a no-op: taxService talks to the upstream API directly and
+// has nothing cached to drop. Use cachedTaxService for cache invalidation.
+func (s *taxService) InvalidateCache(year string) {}
+
+func (s *taxService) CalculateTax(ctx context.Context, income float64, year string) (*models.TaxCalculationResponse, error) {
 	// Get tax brackets
-	brackets, err := s.GetTaxBrackets(year)
+	brackets, err := s.GetTaxBrackets(ctx, year)
 	if err != nil {
-		log.Error().Msgf("Error getting tax brackets: %v", err)
+		log.Error().Str("request_id", requestid.FromContext(ctx)).Msgf("Error getting tax brackets: %v", err)
 		return nil, err
 	}
 
+	response, err := calculateTax(income, year, brackets)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("Tax calculation completed for income %.2f in year %s: total tax %.2f",
+		income, year, response.TotalTax)
+
+	return response, nil
+}
+
+// calculateTax validates that brackets were actually returned for year, then
+// applies them to income. Shared by every TaxService implementation so none
+// of them can skip the empty-brackets guard.
+func calculateTax(income float64, year string, brackets []models.TaxBracket) (*models.TaxCalculationResponse, error) {
 	if len(brackets) == 0 {
 		return nil, fmt.Errorf("no tax brackets found for year %s", year)
 	}
 
+	return calculateTaxFromBrackets(income, year, brackets), nil
+}
+
+// calculateTaxFromBrackets applies the progressive tax brackets to income and
+// builds the full calculation response. It has no dependency on how the
+// brackets were obtained, so it can be shared by any TaxService implementation.
+func calculateTaxFromBrackets(income float64, year string, brackets []models.TaxBracket) *models.TaxCalculationResponse {
 	// Calculate taxes
 	totalTax := 0.0
 	taxesByBand := []models.BandTaxDetail{}
@@ -155,7 +336,7 @@ func (s *taxService) CalculateTax(income float64, year string) (*models.TaxCalcu
 		effectiveRate = (totalTax / income) * 100
 	}
 
-	response := &models.TaxCalculationResponse{
+	return &models.TaxCalculationResponse{
 		Income:         income,
 		TaxYear:        year,
 		TotalTax:       math.Round(totalTax*100) / 100,
@@ -163,9 +344,4 @@ func (s *taxService) CalculateTax(income float64, year string) (*models.TaxCalcu
 		EffectiveRate:  math.Round(effectiveRate*100) / 100,
 		AfterTaxIncome: math.Round((income-totalTax)*100) / 100,
 	}
-
-	log.Info().Msgf("Tax calculation completed for income %.2f in year %s: total tax %.2f",
-		income, year, response.TotalTax)
-
-	return response, nil
 }
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/thevibhu/plusgrade-tax-calculator/internal/models"
@@ -30,7 +33,7 @@ func TestCalculateTax(t *testing.T) {
 	}))
 	defer server.Close()
 
-	service := NewTaxService(server.URL)
+	service := NewTaxService(server.URL, DefaultRetryPolicy())
 
 	// Test cases for successful calculations
 	testCases := []struct {
@@ -47,7 +50,7 @@ func TestCalculateTax(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result, err := service.CalculateTax(tc.income, "2022")
+			result, err := service.CalculateTax(context.Background(), tc.income, "2022")
 			assert.NoError(t, err)
 			assert.NotNil(t, result)
 			assert.InDelta(t, tc.expectedTax, result.TotalTax, 0.01)
@@ -62,8 +65,8 @@ func TestCalculateTax(t *testing.T) {
 		}))
 		defer errorServer.Close()
 
-		errorService := NewTaxService(errorServer.URL)
-		result, err := errorService.CalculateTax(50000, "2023")
+		errorService := NewTaxService(errorServer.URL, DefaultRetryPolicy())
+		result, err := errorService.CalculateTax(context.Background(), 50000, "2023")
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -80,8 +83,8 @@ func TestGetTaxBrackets(t *testing.T) {
 		}))
 		defer server.Close()
 
-		service := NewTaxService(server.URL)
-		brackets, err := service.GetTaxBrackets("2022")
+		service := NewTaxService(server.URL, DefaultRetryPolicy())
+		brackets, err := service.GetTaxBrackets(context.Background(), "2022")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, brackets)
@@ -96,8 +99,8 @@ func TestGetTaxBrackets(t *testing.T) {
 		}))
 		defer server.Close()
 
-		service := NewTaxService(server.URL)
-		brackets, err := service.GetTaxBrackets("2022")
+		service := NewTaxService(server.URL, DefaultRetryPolicy())
+		brackets, err := service.GetTaxBrackets(context.Background(), "2022")
 
 		assert.Error(t, err)
 		assert.Nil(t, brackets)
@@ -117,8 +120,8 @@ func TestGetTaxBrackets(t *testing.T) {
 		}))
 		defer server.Close()
 
-		service := NewTaxService(server.URL)
-		brackets, err := service.GetTaxBrackets("invalid-year")
+		service := NewTaxService(server.URL, DefaultRetryPolicy())
+		brackets, err := service.GetTaxBrackets(context.Background(), "invalid-year")
 
 		assert.Error(t, err)
 		assert.Nil(t, brackets)
@@ -137,10 +140,75 @@ func TestGetTaxBrackets(t *testing.T) {
 		}))
 		defer server.Close()
 
-		service := NewTaxService(server.URL)
-		brackets, err := service.GetTaxBrackets("2022")
+		service := NewTaxService(server.URL, DefaultRetryPolicy())
+		brackets, err := service.GetTaxBrackets(context.Background(), "2022")
 
 		assert.Error(t, err)
 		assert.Nil(t, brackets)
 	})
+
+	t.Run("Retries on transient failure then succeeds", func(t *testing.T) {
+		var requestCount int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requestCount, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintln(w, `{"tax_brackets":[{"min":0,"max":50197,"rate":0.15}]}`)
+		}))
+		defer server.Close()
+
+		service := NewTaxService(server.URL, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+		brackets, err := service.GetTaxBrackets(context.Background(), "2022")
+
+		assert.NoError(t, err)
+		assert.Len(t, brackets, 1)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requestCount))
+	})
+
+	t.Run("Honors Retry-After header on persistent rate limiting", func(t *testing.T) {
+		var requestCount int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		service := NewTaxService(server.URL, RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+
+		start := time.Now()
+		brackets, err := service.GetTaxBrackets(context.Background(), "2022")
+		elapsed := time.Since(start)
+
+		assert.Error(t, err)
+		assert.Nil(t, brackets)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requestCount))
+		assert.GreaterOrEqual(t, elapsed, 2*time.Second)
+	})
+
+	t.Run("Cancelled context aborts a slow upstream request quickly", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+		defer server.Close()
+
+		service := NewTaxService(server.URL, DefaultRetryPolicy())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		brackets, err := service.GetTaxBrackets(ctx, "2022")
+		elapsed := time.Since(start)
+
+		assert.Error(t, err)
+		assert.Nil(t, brackets)
+		assert.Less(t, elapsed, 100*time.Millisecond)
+	})
 }